@@ -0,0 +1,33 @@
+package pace
+
+import "time"
+
+// Report carries the full context for a single report tick, including any
+// tags attached via WithTags. It is passed to reporters created with
+// NewWithOptions instead of the plain (label, timeframe, value) triple.
+type Report struct {
+	Label     string
+	Timeframe time.Duration
+	Value     float64
+	Tags      map[string]string
+}
+
+// ReporterFuncV2 is like ReporterFunc, but receives a Report carrying any
+// tags attached to the Pace via WithTags. Use it with NewWithOptions.
+type ReporterFuncV2 func(r Report)
+
+// Option configures optional metadata for a Pace created with NewWithOptions.
+type Option func(*options)
+
+type options struct {
+	tags map[string]string
+}
+
+// WithTags attaches tags to every report emitted by a Pace created with
+// NewWithOptions, made available to tag-aware reporters (ReporterFuncV2)
+// through Report.Tags.
+func WithTags(tags map[string]string) Option {
+	return func(o *options) {
+		o.tags = tags
+	}
+}
@@ -2,6 +2,7 @@
 package pace
 
 import (
+	"context"
 	"log"
 	"strconv"
 	"sync"
@@ -20,6 +21,10 @@ type Pace interface {
 	// Report manually triggers a report with time frame less than the defined interval.
 	// Specify a custom reporter function just for this one report.
 	Report(reporter ReporterFunc)
+	// Stop stops the reporter goroutine and its underlying timer, emitting one
+	// last report for whatever accumulated since the previous tick. Stop is
+	// idempotent and blocks until the reporter goroutine has exited.
+	Stop()
 }
 
 type paceImpl struct {
@@ -31,7 +36,13 @@ type paceImpl struct {
 	interval time.Duration
 	lastTick time.Time
 	repFn    ReporterFunc
+	repFnV2  ReporterFuncV2
+	tags     map[string]string
 	t        *time.Timer
+
+	quit     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
 }
 
 func (p *paceImpl) Step(n float64) {
@@ -80,16 +91,28 @@ func (p *paceImpl) Report(reporter ReporterFunc) {
 	}
 }
 
+func (p *paceImpl) Stop() {
+	p.stopOnce.Do(func() {
+		close(p.quit)
+		<-p.done
+	})
+}
+
 func (p *paceImpl) report(reporter ReporterFunc) {
-	if reporter == nil {
-		reporter = p.repFn
-	}
 	timeframe := time.Since(p.lastTick)
 	if abs(timeframe-p.interval) < 10*time.Millisecond {
 		timeframe = p.interval
 	}
 	label := p.label
 	value := p.value
+
+	if reporter == nil && p.repFnV2 != nil {
+		p.repFnV2(Report{Label: label, Timeframe: timeframe, Value: value, Tags: p.tags})
+		return
+	}
+	if reporter == nil {
+		reporter = p.repFn
+	}
 	reporter(label, timeframe, value)
 }
 
@@ -97,7 +120,7 @@ func (p *paceImpl) report(reporter ReporterFunc) {
 // All ticks (or steps) are aggregated in timeframes specified using interval.
 func New(label string, interval time.Duration, repFn ReporterFunc) Pace {
 	if repFn == nil {
-		repFn = DefaultReporter
+		repFn = NewDefaultReporter()
 	}
 	p := &paceImpl{
 		mux: new(sync.RWMutex),
@@ -107,18 +130,90 @@ func New(label string, interval time.Duration, repFn ReporterFunc) Pace {
 		repFn:    repFn,
 		lastTick: time.Now(),
 		t:        time.NewTimer(interval),
+
+		quit: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	go func() {
+		defer close(p.done)
+		for {
+			select {
+			case <-p.t.C:
+				p.mux.Lock()
+				p.report(nil)
+
+				p.value = 0
+				p.lastTick = time.Now()
+				p.t.Reset(interval)
+				p.mux.Unlock()
+			case <-p.quit:
+				p.t.Stop()
+				p.mux.Lock()
+				p.report(nil)
+				p.mux.Unlock()
+				return
+			}
+		}
+	}()
+	return p
+}
+
+// NewWithOptions is like New, but takes a tag-aware ReporterFuncV2 and a set
+// of Options (currently just WithTags) whose metadata is threaded through to
+// every report as a Report value.
+func NewWithOptions(label string, interval time.Duration, repFn ReporterFuncV2, opts ...Option) Pace {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	p := &paceImpl{
+		mux: new(sync.RWMutex),
+
+		label:    label,
+		interval: interval,
+		repFnV2:  repFn,
+		tags:     o.tags,
+		lastTick: time.Now(),
+		t:        time.NewTimer(interval),
+
+		quit: make(chan struct{}),
+		done: make(chan struct{}),
 	}
 	go func() {
-		for range p.t.C {
-			func() {
+		defer close(p.done)
+		for {
+			select {
+			case <-p.t.C:
 				p.mux.Lock()
-				defer p.mux.Unlock()
 				p.report(nil)
 
 				p.value = 0
 				p.lastTick = time.Now()
 				p.t.Reset(interval)
-			}()
+				p.mux.Unlock()
+			case <-p.quit:
+				p.t.Stop()
+				p.mux.Lock()
+				p.report(nil)
+				p.mux.Unlock()
+				return
+			}
+		}
+	}()
+	return p
+}
+
+// NewWithContext is identical to New, except the returned Pace also stops
+// itself, as if Stop was called, when ctx is cancelled.
+func NewWithContext(ctx context.Context, label string, interval time.Duration, repFn ReporterFunc) Pace {
+	p := New(label, interval, repFn).(*paceImpl)
+	go func() {
+		select {
+		case <-ctx.Done():
+			p.Stop()
+		case <-p.done:
+			// stopped some other way (e.g. a direct Stop() call); nothing
+			// left to watch for, so exit instead of blocking on ctx forever.
 		}
 	}()
 	return p
@@ -127,10 +222,13 @@ func New(label string, interval time.Duration, repFn ReporterFunc) Pace {
 // ReporterFunc defines a function used to report current pace.
 type ReporterFunc func(label string, timeframe time.Duration, value float64)
 
-// DefaultReporter reports using log.Printf.
+// DefaultReporter reports using log.Printf. It is stateless and therefore
+// does not detect stalls; use NewDefaultReporter for that. Kept for
+// backward compatibility with callers that reference DefaultReporter
+// directly as a ReporterFunc.
 func DefaultReporter(label string, timeframe time.Duration, value float64) {
 	floatFmt := func(f float64) string {
-		return strconv.FormatFloat(value, 'f', -1, 64)
+		return strconv.FormatFloat(f, 'f', -1, 64)
 	}
 	switch timeframe {
 	case time.Second:
@@ -147,6 +245,56 @@ func DefaultReporter(label string, timeframe time.Duration, value float64) {
 	}
 }
 
+// NewDefaultReporter returns a ReporterFunc backed by log.Printf, like
+// DefaultReporter, but additionally tracks stalls: once a stream of steps
+// goes idle (value reports as 0 after having been non-zero), it logs how
+// long the stall has lasted instead of repeating "0/s" every tick. The
+// returned reporter carries per-instance state, so use a fresh one per
+// Pace (New does this automatically when repFn is nil).
+func NewDefaultReporter() ReporterFunc {
+	var previous float64
+	var stalled time.Time
+
+	return func(label string, timeframe time.Duration, value float64) {
+		switch {
+		case value == 0 && previous == 0:
+			return // don't report anything
+		case value == 0 && previous != 0:
+			dur := timeframe
+			if !stalled.IsZero() {
+				dur = time.Since(stalled)
+				if n := dur / timeframe; dur-n*timeframe < 10*time.Millisecond {
+					dur = n * timeframe
+				}
+			} else {
+				stalled = time.Now().Add(-timeframe)
+			}
+			log.Printf("%s: stalled for %v", label, dur)
+			return
+		default:
+			previous = value
+			stalled = time.Time{}
+		}
+
+		floatFmt := func(f float64) string {
+			return strconv.FormatFloat(f, 'f', -1, 64)
+		}
+		switch timeframe {
+		case time.Second:
+			log.Printf("%s: %s/s in %v", label, floatFmt(value), timeframe)
+		case time.Minute:
+			log.Printf("%s: %s/m in %v", label, floatFmt(value), timeframe)
+		case time.Hour:
+			log.Printf("%s: %s/h in %v", label, floatFmt(value), timeframe)
+		case 24 * time.Hour:
+			log.Printf("%s: %s/day in %v", label, floatFmt(value), timeframe)
+		default:
+			log.Printf("%s %s in %v (pace: %s/s)", floatFmt(value), label,
+				timeframe, floatFmt(value/timeframe.Seconds()))
+		}
+	}
+}
+
 func abs(v time.Duration) time.Duration {
 	if v < 0 {
 		return -v
@@ -0,0 +1,142 @@
+package pace
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// paceAtomicImpl is a lock-free variant of paceImpl: Step never takes a lock,
+// the accumulated value lives in a uint64 as a bit-packed float64
+// and is updated with a compare-and-swap loop. Pause/Resume/Report still
+// take the mutex since they touch the timer and paused state, which are
+// mutated far less often than Step is called.
+type paceAtomicImpl struct {
+	mux *sync.Mutex
+
+	value    uint64 // bit-packed float64, accessed only via sync/atomic
+	label    string
+	paused   bool
+	interval time.Duration
+	lastTick time.Time
+	repFn    ReporterFunc
+	t        *time.Timer
+
+	quit     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+func (p *paceAtomicImpl) Step(n float64) {
+	for {
+		old := atomic.LoadUint64(&p.value)
+		next := math.Float64bits(math.Float64frombits(old) + n)
+		if atomic.CompareAndSwapUint64(&p.value, old, next) {
+			return
+		}
+	}
+}
+
+func (p *paceAtomicImpl) Pause() {
+	p.t.Stop()
+
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	p.report(nil)
+
+	p.paused = true
+	p.lastTick = time.Now()
+}
+
+func (p *paceAtomicImpl) Resume(interval time.Duration) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	p.report(nil)
+
+	p.paused = false
+	p.lastTick = time.Now()
+	if interval > 0 {
+		// override the interval if provided
+		p.interval = interval
+	}
+	p.t.Reset(p.interval)
+}
+
+func (p *paceAtomicImpl) Report(reporter ReporterFunc) {
+	p.t.Stop()
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	p.report(reporter)
+
+	p.lastTick = time.Now()
+	if !p.paused {
+		p.t.Reset(p.interval)
+	}
+}
+
+func (p *paceAtomicImpl) Stop() {
+	p.stopOnce.Do(func() {
+		close(p.quit)
+		<-p.done
+	})
+}
+
+func (p *paceAtomicImpl) report(reporter ReporterFunc) {
+	if reporter == nil {
+		reporter = p.repFn
+	}
+	timeframe := time.Since(p.lastTick)
+	if abs(timeframe-p.interval) < 10*time.Millisecond {
+		timeframe = p.interval
+	}
+	label := p.label
+	// SwapUint64 both reads and resets the counter atomically, so Step
+	// callers never observe a torn value while a report is in flight.
+	value := math.Float64frombits(atomic.SwapUint64(&p.value, 0))
+	reporter(label, timeframe, value)
+}
+
+// NewAtomic creates a new pace meter identical in behavior to New, but with
+// a lock-free Step: the counter is kept in a uint64 (bit-packed float64)
+// updated via sync/atomic compare-and-swap, so concurrent callers never block
+// on a mutex. Only Pause, Resume and Report take a lock, since they are
+// called far less often than Step on hot paths.
+func NewAtomic(label string, interval time.Duration, repFn ReporterFunc) Pace {
+	if repFn == nil {
+		repFn = NewDefaultReporter()
+	}
+	p := &paceAtomicImpl{
+		mux: new(sync.Mutex),
+
+		label:    label,
+		interval: interval,
+		repFn:    repFn,
+		lastTick: time.Now(),
+		t:        time.NewTimer(interval),
+
+		quit: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	go func() {
+		defer close(p.done)
+		for {
+			select {
+			case <-p.t.C:
+				p.mux.Lock()
+				p.report(nil)
+
+				p.lastTick = time.Now()
+				p.t.Reset(interval)
+				p.mux.Unlock()
+			case <-p.quit:
+				p.t.Stop()
+				p.mux.Lock()
+				p.report(nil)
+				p.mux.Unlock()
+				return
+			}
+		}
+	}()
+	return p
+}
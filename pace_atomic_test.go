@@ -0,0 +1,29 @@
+package pace
+
+import (
+	"testing"
+	"time"
+)
+
+// BenchmarkStepMutex and BenchmarkStepAtomic measure Step throughput under
+// concurrent callers. Run with -cpu=1,4,16 to see the mutex implementation
+// degrade as goroutines contend for the lock, while the atomic one scales.
+func BenchmarkStepMutex(b *testing.B) {
+	p := New("bench", time.Hour, func(string, time.Duration, float64) {})
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			p.Step(1)
+		}
+	})
+}
+
+func BenchmarkStepAtomic(b *testing.B) {
+	p := NewAtomic("bench", time.Hour, func(string, time.Duration, float64) {})
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			p.Step(1)
+		}
+	})
+}
@@ -1,7 +1,10 @@
 package pace
 
 import (
+	"bytes"
+	"context"
 	"log"
+	"runtime"
 	"sync"
 	"testing"
 	"time"
@@ -43,4 +46,131 @@ func TestSimple(t *testing.T) {
 	time.Sleep(3 * time.Second)
 	p.Report(nil)
 	log.Println("done")
+
+	p.Stop()
+	p.Stop() // must be idempotent
+}
+
+// TestNewDefaultReporterStall checks that the stall-aware reporter stays
+// quiet while idle from the start, then logs a stall once a previously
+// active stream goes to zero, and resumes normal reporting afterwards.
+func TestNewDefaultReporterStall(t *testing.T) {
+	var buf bytes.Buffer
+	origOut, origFlags := log.Writer(), log.Flags()
+	log.SetOutput(&buf)
+	log.SetFlags(0)
+	defer func() {
+		log.SetOutput(origOut)
+		log.SetFlags(origFlags)
+	}()
+
+	reporter := NewDefaultReporter()
+
+	reporter("items", timeframe, 0)  // idle from the start: no previous report
+	reporter("items", timeframe, 10) // active
+	reporter("items", timeframe, 0)  // just stalled
+	reporter("items", timeframe, 0)  // still stalled
+	reporter("items", timeframe, 5)  // active again
+
+	want := "items: 10/s in 1s\nitems: stalled for 1s\nitems: stalled for 1s\nitems: 5/s in 1s\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("expected log output %q, got %q", want, got)
+	}
+}
+
+// TestNewWithOptionsThreadsTags drives a Pace created with NewWithOptions
+// through a manual Report(nil), so the ReporterFuncV2 sees a Report populated by
+// paceImpl.report's repFnV2/tags wiring, not one built by hand.
+func TestNewWithOptionsThreadsTags(t *testing.T) {
+	reported := make(chan Report, 1)
+	tags := map[string]string{"env": "prod", "region": "eu"}
+
+	po := NewWithOptions("tagged-pace", time.Hour, func(r Report) {
+		reported <- r
+	}, WithTags(tags))
+	defer po.Stop()
+
+	po.Step(7)
+	po.Report(nil)
+
+	select {
+	case r := <-reported:
+		if r.Label != "tagged-pace" {
+			t.Fatalf("expected label %q, got %q", "tagged-pace", r.Label)
+		}
+		if r.Value != 7 {
+			t.Fatalf("expected value 7, got %v", r.Value)
+		}
+		if len(r.Tags) != len(tags) || r.Tags["env"] != "prod" || r.Tags["region"] != "eu" {
+			t.Fatalf("expected tags %v, got %v", tags, r.Tags)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for ReporterFuncV2 to be invoked")
+	}
+}
+
+// TestStopJoinsGoroutine checks that Stop() actually waits for the reporter
+// goroutine to exit rather than just signalling it, by ensuring the
+// goroutine count returns to its baseline afterwards.
+func TestStopJoinsGoroutine(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	p := New("leak-check", time.Millisecond, func(string, time.Duration, float64) {})
+	p.Step(1)
+	p.Stop()
+
+	// allow the scheduler a moment to actually tear the goroutine down
+	time.Sleep(10 * time.Millisecond)
+	runtime.Gosched()
+
+	after := runtime.NumGoroutine()
+	if after > before {
+		t.Fatalf("goroutine leak: before=%d after=%d", before, after)
+	}
+}
+
+// TestNewWithContext checks that cancelling the context stops the pace
+// meter the same way an explicit Stop() would.
+func TestNewWithContext(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	reported := make(chan float64, 1)
+	p := NewWithContext(ctx, "ctx-pace", time.Hour, func(label string, timeframe time.Duration, value float64) {
+		reported <- value
+	})
+	p.Step(42)
+	cancel()
+
+	select {
+	case value := <-reported:
+		if value != 42 {
+			t.Fatalf("expected final report of 42, got %v", value)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for final report after context cancellation")
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	runtime.Gosched()
+	if after := runtime.NumGoroutine(); after > before {
+		t.Fatalf("goroutine leak: before=%d after=%d", before, after)
+	}
+}
+
+// TestNewWithContextDirectStop checks that calling Stop() directly on a
+// Pace created with NewWithContext, without ever cancelling ctx, still
+// tears down the context-watcher goroutine instead of leaving it parked on
+// <-ctx.Done() forever.
+func TestNewWithContextDirectStop(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	p := NewWithContext(context.Background(), "ctx-pace-direct-stop", time.Hour, func(string, time.Duration, float64) {})
+	p.Stop()
+
+	time.Sleep(10 * time.Millisecond)
+	runtime.Gosched()
+	if after := runtime.NumGoroutine(); after > before {
+		t.Fatalf("goroutine leak: before=%d after=%d", before, after)
+	}
 }
@@ -0,0 +1,121 @@
+// Package pace (reporters/ewma) provides burst-sensitive ReporterFunc
+// wrappers for github.com/xlab/pace: exponentially-weighted moving
+// averages and sliding-window percentiles of the per-tick rate.
+package pace
+
+import (
+	"log"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/xlab/pace"
+)
+
+// EWMAReporter wraps next with 1m/5m/15m exponentially-weighted moving
+// averages of the per-interval rate (value / timeframe), logged on every
+// tick before handing the report on to next. On the first tick, each
+// average is seeded with the instantaneous rate.
+func EWMAReporter(next pace.ReporterFunc) pace.ReporterFunc {
+	const (
+		window1m  = 60.0
+		window5m  = 300.0
+		window15m = 900.0
+	)
+
+	var mux sync.Mutex
+	var seeded bool
+	var ewma1m, ewma5m, ewma15m float64
+
+	return func(label string, timeframe time.Duration, value float64) {
+		rate := value / timeframe.Seconds()
+
+		mux.Lock()
+		if !seeded {
+			ewma1m, ewma5m, ewma15m = rate, rate, rate
+			seeded = true
+		} else {
+			secs := timeframe.Seconds()
+			ewma1m += alpha(secs, window1m) * (rate - ewma1m)
+			ewma5m += alpha(secs, window5m) * (rate - ewma5m)
+			ewma15m += alpha(secs, window15m) * (rate - ewma15m)
+		}
+		m1, m5, m15 := ewma1m, ewma5m, ewma15m
+		mux.Unlock()
+
+		log.Printf("%s: ewma1m=%.3f/s ewma5m=%.3f/s ewma15m=%.3f/s", label, m1, m5, m15)
+
+		if next != nil {
+			next(label, timeframe, value)
+		}
+	}
+}
+
+func alpha(timeframeSeconds, windowSeconds float64) float64 {
+	return 1 - math.Exp(-timeframeSeconds/windowSeconds)
+}
+
+// Option configures a PercentileReporter.
+type Option func(*config)
+
+type config struct {
+	windowSize int
+}
+
+// WithWindowSize overrides the default 600-sample ring buffer (10 minutes
+// of history at a 1s report interval).
+func WithWindowSize(n int) Option {
+	return func(c *config) {
+		c.windowSize = n
+	}
+}
+
+// PercentileReporter wraps next, keeping a ring buffer of the last N
+// per-tick rates (value / timeframe, default 600 samples) and logging
+// p50/p90/p99 computed from a sorted copy of the buffer on every tick
+// before handing the report on to next.
+func PercentileReporter(next pace.ReporterFunc, opts ...Option) pace.ReporterFunc {
+	cfg := config{windowSize: 600}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var mux sync.Mutex
+	ring := make([]float64, 0, cfg.windowSize)
+	pos := 0
+
+	return func(label string, timeframe time.Duration, value float64) {
+		rate := value / timeframe.Seconds()
+
+		mux.Lock()
+		if len(ring) < cfg.windowSize {
+			ring = append(ring, rate)
+		} else {
+			ring[pos] = rate
+			pos = (pos + 1) % cfg.windowSize
+		}
+		snapshot := make([]float64, len(ring))
+		copy(snapshot, ring)
+		mux.Unlock()
+
+		sort.Float64s(snapshot)
+		log.Printf("%s: p50=%.3f/s p90=%.3f/s p99=%.3f/s", label,
+			percentile(snapshot, 0.50), percentile(snapshot, 0.90), percentile(snapshot, 0.99))
+
+		if next != nil {
+			next(label, timeframe, value)
+		}
+	}
+}
+
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(p * float64(len(sorted)))
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+	return sorted[idx]
+}
@@ -0,0 +1,195 @@
+// Package pace (reporters/history) provides a windowed time-series backend
+// for github.com/xlab/pace: a Series folds each report tick into one or
+// more retention resolutions (e.g. 60x1s, 60x1m, 24x1h, 7x1d) and exposes a
+// small query API plus a JSON dashboard handler.
+package pace
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Resolution describes one retention bucket size: Bucket is the width of a
+// single bucket, and Retain is how many of them to keep.
+type Resolution struct {
+	Bucket time.Duration
+	Retain int
+}
+
+// Bucket is one bucketed sum of reported values, starting at Start and
+// covering [Start, Start+resolution.Bucket).
+type Bucket struct {
+	Start time.Time `json:"start"`
+	Value float64   `json:"value"`
+}
+
+type resState struct {
+	start time.Time
+	sum   float64
+}
+
+// Series folds reported values into fixed-size circular buffers at one or
+// more resolutions, finest first. It implements pace.ReporterFunc via its
+// Report method, so it can be plugged directly into pace.New as the
+// reporter.
+type Series struct {
+	mux         sync.RWMutex
+	label       string
+	resolutions []Resolution
+	state       []resState
+	rings       [][]Bucket
+}
+
+// NewSeries creates a Series that folds every reported value into each of
+// the given resolutions. Resolutions are kept sorted finest-to-coarsest
+// internally, since coarser buckets are built by summing the finer buckets
+// that fall inside them as they roll over.
+func NewSeries(label string, resolutions ...Resolution) *Series {
+	sorted := append([]Resolution(nil), resolutions...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Bucket < sorted[j].Bucket })
+	return &Series{
+		label:       label,
+		resolutions: sorted,
+		state:       make([]resState, len(sorted)),
+		rings:       make([][]Bucket, len(sorted)),
+	}
+}
+
+// Report implements pace.ReporterFunc: it folds value into the current
+// bucket of the finest resolution, rolling it if it has crossed its boundary
+// and cascading each sealed sum into the next coarser resolution, so coarser
+// resolutions only see value once the finer bucket holding it has sealed.
+func (s *Series) Report(label string, timeframe time.Duration, value float64) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.fold(0, time.Now(), value)
+}
+
+func (s *Series) fold(i int, now time.Time, value float64) {
+	if i >= len(s.resolutions) {
+		return
+	}
+	res := s.resolutions[i]
+	st := &s.state[i]
+	if st.start.IsZero() {
+		st.start = now.Truncate(res.Bucket)
+	}
+	for now.Sub(st.start) >= res.Bucket {
+		sealedStart, sealedValue := st.start, st.sum
+		s.push(i, sealedStart, sealedValue)
+		st.start = st.start.Add(res.Bucket)
+		st.sum = 0
+		s.fold(i+1, sealedStart, sealedValue)
+	}
+	st.sum += value
+}
+
+func (s *Series) push(i int, start time.Time, value float64) {
+	retain := s.resolutions[i].Retain
+	ring := append(s.rings[i], Bucket{Start: start, Value: value})
+	if len(ring) > retain {
+		ring = ring[len(ring)-retain:]
+	}
+	s.rings[i] = ring
+}
+
+func (s *Series) indexOf(res Resolution) int {
+	for i, r := range s.resolutions {
+		if r.Bucket == res.Bucket {
+			return i
+		}
+	}
+	return -1
+}
+
+// Range returns the sealed buckets of resolution res whose start time falls
+// in [from, to).
+func (s *Series) Range(res Resolution, from, to time.Time) []Bucket {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	idx := s.indexOf(res)
+	if idx < 0 {
+		return nil
+	}
+	var out []Bucket
+	for _, b := range s.rings[idx] {
+		if !b.Start.Before(from) && b.Start.Before(to) {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+// Rate returns the average per-second rate of resolution res over the
+// trailing window, including a prorated share of whatever has accumulated
+// in the current, not-yet-sealed bucket.
+func (s *Series) Rate(res Resolution, window time.Duration) float64 {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	idx := s.indexOf(res)
+	if idx < 0 {
+		return 0
+	}
+	now := time.Now()
+	cutoff := now.Add(-window)
+
+	var sum float64
+	for _, b := range s.rings[idx] {
+		if !b.Start.Before(cutoff) {
+			sum += b.Value
+		}
+	}
+
+	// The in-flight bucket hasn't sealed yet, so it may span far more wall
+	// time than window (e.g. an hour-long bucket queried with a 1-minute
+	// window). Assume its accumulated value is spread evenly across the
+	// time it's been open, and only add the share that falls within
+	// [cutoff, now).
+	st := s.state[idx]
+	if elapsed := now.Sub(st.start); elapsed > 0 && st.sum != 0 {
+		overlapStart := st.start
+		if cutoff.After(overlapStart) {
+			overlapStart = cutoff
+		}
+		if overlap := now.Sub(overlapStart); overlap >= elapsed {
+			sum += st.sum
+		} else if overlap > 0 {
+			sum += st.sum * overlap.Seconds() / elapsed.Seconds()
+		}
+	}
+
+	return sum / window.Seconds()
+}
+
+type resolutionDump struct {
+	Bucket  string   `json:"bucket"`
+	Retain  int      `json:"retain"`
+	Buckets []Bucket `json:"buckets"`
+}
+
+// ServeHTTP emits the full series, all resolutions and their sealed
+// buckets, as JSON for a small dashboard.
+func (s *Series) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.RLock()
+	dump := make([]resolutionDump, len(s.resolutions))
+	for i, res := range s.resolutions {
+		dump[i] = resolutionDump{
+			Bucket:  res.Bucket.String(),
+			Retain:  res.Retain,
+			Buckets: append([]Bucket(nil), s.rings[i]...),
+		}
+	}
+	label := s.label
+	s.mux.RUnlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(struct {
+		Label       string           `json:"label"`
+		Resolutions []resolutionDump `json:"resolutions"`
+	}{Label: label, Resolutions: dump})
+}
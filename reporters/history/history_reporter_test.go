@@ -0,0 +1,75 @@
+package pace
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSeriesRollsUpFinerBucketsIntoCoarser(t *testing.T) {
+	seconds := Resolution{Bucket: time.Second, Retain: 10}
+	minutes := Resolution{Bucket: time.Minute, Retain: 10}
+	s := NewSeries("items", seconds, minutes)
+
+	now := time.Now().Truncate(time.Minute)
+	s.state[0].start = now
+	s.state[1].start = now
+
+	for i := 0; i < 60; i++ {
+		s.fold(0, now.Add(time.Duration(i)*time.Second), 1)
+	}
+	// force the last in-flight second bucket, and then the now-complete
+	// minute bucket, to seal
+	s.fold(0, now.Add(60*time.Second), 0)
+	s.fold(0, now.Add(61*time.Second), 0)
+
+	secondBuckets := s.Range(seconds, now, now.Add(time.Hour))
+	if len(secondBuckets) != 10 {
+		t.Fatalf("expected ring to retain 10 second-buckets, got %d", len(secondBuckets))
+	}
+
+	minuteBuckets := s.Range(minutes, now, now.Add(time.Hour))
+	if len(minuteBuckets) != 1 {
+		t.Fatalf("expected exactly one sealed minute bucket, got %d", len(minuteBuckets))
+	}
+	if minuteBuckets[0].Value != 60 {
+		t.Fatalf("expected the minute bucket to sum all 60 one-second buckets to 60, got %v", minuteBuckets[0].Value)
+	}
+}
+
+func TestSeriesRate(t *testing.T) {
+	res := Resolution{Bucket: time.Second, Retain: 10}
+	s := NewSeries("items", res)
+
+	s.Report("items", time.Second, 5)
+
+	if rate := s.Rate(res, time.Second); rate != 5 {
+		t.Fatalf("expected rate of 5/s from the in-flight bucket, got %v", rate)
+	}
+}
+
+// TestSeriesRateProratesInFlightBucket guards against treating the entire
+// sum of a long-running in-flight bucket as if it all happened inside a
+// much narrower query window.
+func TestSeriesRateProratesInFlightBucket(t *testing.T) {
+	res := Resolution{Bucket: time.Hour, Retain: 10}
+	s := NewSeries("items", res)
+
+	// 55 units accumulated over the last 30 minutes of an hour-long bucket.
+	s.state[0].start = time.Now().Add(-30 * time.Minute)
+	s.state[0].sum = 55
+
+	// Only the trailing 1 of those 30 minutes falls inside a 1-minute
+	// window, so only 1/30th of the 55 units should count.
+	got := s.Rate(res, time.Minute)
+	want := (55.0 / 30.0) / 60.0 // (prorated units over the window) / window seconds
+	if math.Abs(got-want) > 0.001 {
+		t.Fatalf("expected prorated rate ~%v, got %v", want, got)
+	}
+
+	// Sanity check: the old (buggy) behavior of adding the raw 55 unprorated
+	// would yield roughly 30x this rate.
+	if unprorated := 55.0 / 60.0; math.Abs(got-unprorated) < 0.01 {
+		t.Fatalf("rate looks unprorated: got %v, which matches raw-sum/window %v", got, unprorated)
+	}
+}
@@ -0,0 +1,131 @@
+// Package pace (reporters/statsd) provides StatsD/DogStatsD ReporterFunc
+// implementations for github.com/xlab/pace.
+package pace
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/xlab/pace"
+)
+
+// maxDatagramSize caps how many bytes are buffered before a flush, keeping
+// each write under a typical Ethernet MTU so it fits in a single UDP
+// datagram.
+const maxDatagramSize = 1400
+
+// Option configures a StatsD/DogStatsD reporter.
+type Option func(*config)
+
+type config struct {
+	maxBufferSize int
+}
+
+// WithMaxBufferSize overrides the default ~1400 byte buffering threshold.
+func WithMaxBufferSize(n int) Option {
+	return func(c *config) {
+		c.maxBufferSize = n
+	}
+}
+
+func newConfig(opts []Option) config {
+	c := config{maxBufferSize: maxDatagramSize}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// buffer accumulates StatsD lines and flushes them to w in chunks no larger
+// than maxBufferSize, so that a single Write maps to a single UDP datagram.
+type buffer struct {
+	mux sync.Mutex
+	w   io.Writer
+	max int
+	buf bytes.Buffer
+}
+
+func (b *buffer) writeLines(lines ...string) error {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	for _, line := range lines {
+		if b.buf.Len()+len(line) > b.max && b.buf.Len() > 0 {
+			if err := b.flushLocked(); err != nil {
+				return err
+			}
+		}
+		b.buf.WriteString(line)
+	}
+	return b.flushLocked()
+}
+
+func (b *buffer) flushLocked() error {
+	if b.buf.Len() == 0 {
+		return nil
+	}
+	_, err := b.w.Write(b.buf.Bytes())
+	b.buf.Reset()
+	return err
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func formatTags(tags map[string]string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	buf.WriteString("|#")
+	for i, k := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(k)
+		buf.WriteByte(':')
+		buf.WriteString(tags[k])
+	}
+	return buf.String()
+}
+
+// StatsDReporter returns a pace.ReporterFunc that writes plain StatsD lines
+// to w on every tick: a counter `<label>:<value>|c` and a gauge
+// `<label>.rate:<value/sec>|g`. Writes are buffered up to ~1400 bytes so a
+// single report maps to a single UDP datagram.
+func StatsDReporter(w io.Writer, opts ...Option) pace.ReporterFunc {
+	buf := &buffer{w: w, max: newConfig(opts).maxBufferSize}
+
+	return func(label string, timeframe time.Duration, value float64) {
+		counter := fmt.Sprintf("%s:%s|c\n", label, formatFloat(value))
+		gauge := fmt.Sprintf("%s.rate:%s|g\n", label, formatFloat(value/timeframe.Seconds()))
+		buf.writeLines(counter, gauge)
+	}
+}
+
+// DogStatsDReporter returns a pace.ReporterFuncV2 that writes DogStatsD
+// lines to w, identical to StatsDReporter but with a `|#k:v,...` tag suffix
+// sourced from pace.Report.Tags (set via pace.WithTags on
+// pace.NewWithOptions).
+func DogStatsDReporter(w io.Writer, opts ...Option) pace.ReporterFuncV2 {
+	buf := &buffer{w: w, max: newConfig(opts).maxBufferSize}
+
+	return func(r pace.Report) {
+		tags := formatTags(r.Tags)
+		counter := fmt.Sprintf("%s:%s|c%s\n", r.Label, formatFloat(r.Value), tags)
+		gauge := fmt.Sprintf("%s.rate:%s|g%s\n", r.Label, formatFloat(r.Value/r.Timeframe.Seconds()), tags)
+		buf.writeLines(counter, gauge)
+	}
+}
@@ -0,0 +1,81 @@
+package pace
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xlab/pace"
+)
+
+func TestStatsDReporter(t *testing.T) {
+	conn, addr := listen(t)
+	defer conn.Close()
+
+	client, err := net.Dial("udp", addr.String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer client.Close()
+
+	reporter := StatsDReporter(client)
+	reporter("items", time.Second, 10)
+
+	lines := readLines(t, conn)
+	assertLine(t, lines, "items:10|c")
+	assertLine(t, lines, "items.rate:10|g")
+}
+
+func TestDogStatsDReporterWithTags(t *testing.T) {
+	conn, addr := listen(t)
+	defer conn.Close()
+
+	client, err := net.Dial("udp", addr.String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer client.Close()
+
+	reporter := DogStatsDReporter(client)
+	reporter(pace.Report{
+		Label:     "items",
+		Timeframe: time.Second,
+		Value:     10,
+		Tags:      map[string]string{"env": "prod", "region": "eu"},
+	})
+
+	lines := readLines(t, conn)
+	assertLine(t, lines, "items:10|c|#env:prod,region:eu")
+	assertLine(t, lines, "items.rate:10|g|#env:prod,region:eu")
+}
+
+func listen(t *testing.T) (net.PacketConn, net.Addr) {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	return conn, conn.LocalAddr()
+}
+
+func readLines(t *testing.T, conn net.PacketConn) []string {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	buf := make([]byte, 1500)
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	return strings.Split(strings.TrimRight(string(buf[:n]), "\n"), "\n")
+}
+
+func assertLine(t *testing.T, lines []string, want string) {
+	t.Helper()
+	for _, line := range lines {
+		if line == want {
+			return
+		}
+	}
+	t.Fatalf("expected line %q, got %v", want, lines)
+}